@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xanzy/go-gitlab"
+	"golang.org/x/sync/errgroup"
+)
+
+// listAllIssues walks every page of ListProjectIssues, stopping once
+// maxIssues results have been collected. maxIssues <= 0 means unlimited.
+func listAllIssues(git *gitlab.Client, projectPath string, opts *gitlab.ListProjectIssuesOptions, maxIssues int) ([]*gitlab.Issue, error) {
+	var all []*gitlab.Issue
+
+	opts.Page = 1
+	for {
+		issues, resp, err := git.Issues.ListProjectIssues(projectPath, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing issues (page %d): %w", opts.Page, err)
+		}
+
+		all = append(all, issues...)
+		if maxIssues > 0 && len(all) >= maxIssues {
+			all = all[:maxIssues]
+			break
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// fetchIssueDetails fetches GetIssue for each issue across a bounded worker
+// pool, then returns the results sorted by IID so CSV/output ordering stays
+// deterministic regardless of which fetch finished first.
+func fetchIssueDetails(git *gitlab.Client, projectPath string, issues []*gitlab.Issue, concurrency int) ([]*gitlab.Issue, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1, got %d", concurrency)
+	}
+
+	detailed := make([]*gitlab.Issue, len(issues))
+
+	g := new(errgroup.Group)
+	g.SetLimit(concurrency)
+
+	for i, issue := range issues {
+		i, issue := i, issue
+		g.Go(func() error {
+			d, _, err := git.Issues.GetIssue(projectPath, issue.IID)
+			if err != nil {
+				return fmt.Errorf("getting issue #%d: %w", issue.IID, err)
+			}
+			detailed[i] = d
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(detailed, func(i, j int) bool {
+		return detailed[i].IID < detailed[j].IID
+	})
+
+	return detailed, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *gitlab.Client {
+	t.Helper()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("creating test client: %v", err)
+	}
+	return client
+}
+
+func TestListAllIssuesPaginatesAllPages(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/group/project/issues", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+
+		var issues []gitlab.Issue
+		switch page {
+		case "1":
+			issues = []gitlab.Issue{{IID: 1}, {IID: 2}}
+			w.Header().Set("X-Next-Page", "2")
+		case "2":
+			issues = []gitlab.Issue{{IID: 3}}
+			// no X-Next-Page header: this is the last page
+		default:
+			t.Fatalf("unexpected page %q requested", page)
+		}
+
+		json.NewEncoder(w).Encode(issues)
+	})
+
+	client := newTestClient(t, mux)
+
+	issues, err := listAllIssues(client, "group/project", &gitlab.ListProjectIssuesOptions{}, 0)
+	if err != nil {
+		t.Fatalf("listAllIssues returned error: %v", err)
+	}
+
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3", len(issues))
+	}
+	for i, want := range []int{1, 2, 3} {
+		if issues[i].IID != want {
+			t.Errorf("issues[%d].IID = %d, want %d", i, issues[i].IID, want)
+		}
+	}
+}
+
+func TestListAllIssuesRespectsMaxIssues(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/group/project/issues", func(w http.ResponseWriter, r *http.Request) {
+		issues := []gitlab.Issue{{IID: 1}, {IID: 2}, {IID: 3}}
+		w.Header().Set("X-Next-Page", "2")
+		json.NewEncoder(w).Encode(issues)
+	})
+
+	client := newTestClient(t, mux)
+
+	issues, err := listAllIssues(client, "group/project", &gitlab.ListProjectIssuesOptions{}, 2)
+	if err != nil {
+		t.Fatalf("listAllIssues returned error: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2 (maxIssues truncation)", len(issues))
+	}
+}
+
+func TestFetchIssueDetailsOrdersByIIDDespiteOutOfOrderCompletion(t *testing.T) {
+	mux := http.NewServeMux()
+	for _, iid := range []int{1, 2, 3, 4, 5} {
+		iid := iid
+		mux.HandleFunc(fmt.Sprintf("/api/v4/projects/group/project/issues/%d", iid), func(w http.ResponseWriter, r *http.Request) {
+			// Randomized sleep so responses can complete out of IID order.
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+			json.NewEncoder(w).Encode(gitlab.Issue{IID: iid, Title: fmt.Sprintf("issue %d", iid)})
+		})
+	}
+
+	client := newTestClient(t, mux)
+
+	issues := make([]*gitlab.Issue, 0, 5)
+	for _, iid := range []int{5, 4, 3, 2, 1} {
+		issues = append(issues, &gitlab.Issue{IID: iid})
+	}
+
+	detailed, err := fetchIssueDetails(client, "group/project", issues, 5)
+	if err != nil {
+		t.Fatalf("fetchIssueDetails returned error: %v", err)
+	}
+
+	if len(detailed) != 5 {
+		t.Fatalf("got %d issues, want 5", len(detailed))
+	}
+	for i, want := range []int{1, 2, 3, 4, 5} {
+		if detailed[i].IID != want {
+			t.Errorf("detailed[%d].IID = %d, want %d (not sorted by IID)", i, detailed[i].IID, want)
+		}
+	}
+}
+
+func TestFetchIssueDetailsRejectsInvalidConcurrency(t *testing.T) {
+	if _, err := fetchIssueDetails(nil, "group/project", nil, 0); err == nil {
+		t.Error("fetchIssueDetails with concurrency=0 expected an error, got nil")
+	}
+	if _, err := fetchIssueDetails(nil, "group/project", nil, -1); err == nil {
+		t.Error("fetchIssueDetails with concurrency=-1 expected an error, got nil")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+// logger is the process-wide structured logger, configured by initLogger
+// from the -log-level/-log-format flags before any work begins.
+var logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+// initLogger builds the process-wide logger for the given level and
+// format, replacing the package default.
+func initLogger(level, format string) {
+	var lvl slog.Level
+	switch level {
+	case "debug":
+		lvl = slog.LevelDebug
+	case "warn":
+		lvl = slog.LevelWarn
+	case "error":
+		lvl = slog.LevelError
+	default:
+		lvl = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+	if format == "json" {
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	}
+
+	logger = slog.New(handler)
+}
+
+// fatal logs msg at error level with args, then exits the process. It is
+// the only place in the tool allowed to terminate the process outright;
+// helpers return errors instead so they stay testable.
+func fatal(msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
@@ -1,10 +1,8 @@
 package main
 
 import (
-	"encoding/csv"
 	"flag"
 	"fmt"
-	"log"
 	"net/url"
 	"os"
 	"strings"
@@ -17,15 +15,12 @@ import (
 func extractProjectPath(issuesLink string) (string, error) {
 	u, err := url.Parse(issuesLink)
 	if err != nil {
-		log.Printf("Error parsing GitLab issues link: %v\n", err)
-		return "", err
+		return "", fmt.Errorf("parsing GitLab issues link: %w", err)
 	}
 
 	pathSegments := strings.Split(u.Path, "/")
 	if len(pathSegments) < 5 {
-		err := fmt.Errorf("invalid GitLab issues link: %s", issuesLink)
-		log.Printf("Error extracting project path: %v\n", err)
-		return "", err
+		return "", fmt.Errorf("invalid GitLab issues link: %s", issuesLink)
 	}
 
 	return strings.Join(pathSegments[1:5], "/"), nil
@@ -35,61 +30,102 @@ func extractProjectPath(issuesLink string) (string, error) {
 func readGitLabTokenFromFile(tokenFile string) (string, error) {
 	tokenBytes, err := os.ReadFile(tokenFile)
 	if err != nil {
-		log.Printf("Error reading GitLab token file: %v\n", err)
-		return "", err
+		return "", fmt.Errorf("reading GitLab token file: %w", err)
 	}
 	return strings.TrimSpace(string(tokenBytes)), nil
 }
 
+// main dispatches to the "sync" subcommand, or the default list-to-CSV
+// (and friends) behavior when no subcommand is given.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "sync" {
+		runSync(os.Args[2:])
+		return
+	}
+	runList(os.Args[1:])
+}
+
+// runList is the original default behavior: list open issues for the
+// configured project and render them in the requested format.
+func runList(args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
 
 	var releaseLabel string
 	var readyForTest bool
 	var blockerLabel string
-	flag.StringVar(&releaseLabel, "release", "", "Specify the release label")
-	flag.BoolVar(&readyForTest, "ready-for-test", false, "Flag to filter READY-FOR-TEST issues")
-	flag.StringVar(&blockerLabel, "blocker", "", "Specify the blocker label (staging-upgrade or production-upgrade)")
-
-	flag.Parse()
+	var configFile string
+	var instanceName string
+	var maxIssues int
+	var concurrency int
+	var outputFormat string
+	var logLevel string
+	var logFormat string
+	fs.StringVar(&releaseLabel, "release", "", "Specify the release label")
+	fs.BoolVar(&readyForTest, "ready-for-test", false, "Flag to filter READY-FOR-TEST issues")
+	fs.StringVar(&blockerLabel, "blocker", "", "Specify the blocker label (staging-upgrade or production-upgrade)")
+	fs.StringVar(&configFile, "config", "", "Path to config file describing GitLab instances (default: none, use gitlab.com)")
+	fs.StringVar(&instanceName, "instance", "", "Name of the GitLab instance to use from -config (default: config's default_instance)")
+	fs.IntVar(&maxIssues, "max-issues", 0, "Maximum number of issues to fetch across all pages (0 = unlimited)")
+	fs.IntVar(&concurrency, "concurrency", 5, "Number of concurrent GetIssue detail fetches")
+	fs.StringVar(&outputFormat, "format", "csv", "Output format: csv, json, md, or table")
+	fs.StringVar(&logLevel, "log-level", "info", "Log level: debug, info, warn, or error")
+	fs.StringVar(&logFormat, "log-format", "text", "Log format: text or json")
+	var tokenFileFlag string
+	fs.StringVar(&tokenFileFlag, "token-file", "", "Explicit path to a GitLab token file (overrides env vars, keyring, and config)")
+
+	fs.Parse(args)
+
+	initLogger(logLevel, logFormat)
 
 	if releaseLabel == "" && readyForTest {
-		log.Fatal("Release label is required when filtering by READY-FOR-TEST")
+		fatal("release label is required when filtering by READY-FOR-TEST")
 	}
-	log.Println("Starting the program...")
+	logger.Info("starting the program")
 
-	// Set the path to the GitLab token file
-	tokenFile := "~/.gitlab"
-	resolveHome(&tokenFile)
-	log.Printf("Token file path: %s\n", tokenFile)
+	cfg, err := loadConfig(configFile)
+	if err != nil {
+		fatal("failed to load config", "error", err)
+	}
 
-	// Read GitLab token from file
-	gitlabToken, err := readGitLabTokenFromFile(tokenFile)
+	instance, err := resolveInstance(cfg, instanceName)
 	if err != nil {
-		log.Fatalf("Failed to read GitLab token from file: %v", err)
+		fatal("failed to resolve GitLab instance", "error", err)
 	}
 
-	// Remove the "token:" prefix if present
-	gitlabToken = strings.TrimPrefix(gitlabToken, "token:")
-	// log.Printf("GitLab token: %s\n", gitlabToken)
+	gitlabToken, err := resolveToken(tokenFileFlag, instance.TokenFile)
+	if err != nil {
+		fatal("failed to resolve GitLab token", "error", err)
+	}
 
-	gitlabIssuesLink := "https://gitlab.com/f5/volterra/support/technical/-/issues/?sort=created_date&state=opened"
-	log.Printf("GitLab issues link: %s\n", gitlabIssuesLink)
+	// An instance's configured default project path is already a project
+	// path, not a link to round-trip through extractProjectPath (which
+	// assumes the legacy hardcoded link's fixed 4-segment shape).
+	var projectPath string
+	if instance.DefaultProjectPath != "" {
+		projectPath = instance.DefaultProjectPath
+	} else {
+		gitlabIssuesLink := "https://gitlab.com/f5/volterra/support/technical/-/issues/?sort=created_date&state=opened"
+		projectPath, err = extractProjectPath(gitlabIssuesLink)
+		if err != nil {
+			fatal("failed to extract project path", "error", err, "issues_link", gitlabIssuesLink)
+		}
+	}
 
-	// Extract project path from the GitLab issues link
-	projectPath, err := extractProjectPath(gitlabIssuesLink)
+	logger.Info("resolved project", "project_path", projectPath)
+
+	// Create a GitLab client, wired up for the resolved instance's base URL
+	// and TLS settings.
+	git, err := newGitLabClient(gitlabToken, instance)
 	if err != nil {
-		log.Fatalf("Failed to extract project path: %v", err)
+		fatal("failed to create GitLab client", "error", err)
 	}
 
-	log.Printf("Project path: %s\n", projectPath)
-
-	// Create a GitLab client
-	git, err := gitlab.NewClient(gitlabToken)
+	username, err := validateToken(git)
 	if err != nil {
-		log.Fatalf("Failed to create GitLab client: %v", err)
+		fatal("failed to validate GitLab token", "error", err)
 	}
+	logger.Info("authenticated with GitLab", "username", username)
 
-	log.Println("GitLab client created successfully.")
 	opened := "opened"
 	var lab, notLabels gitlab.LabelOptions
 
@@ -107,80 +143,61 @@ func main() {
 		lab = append(lab, blockerLabel)
 	}
 
-	issues, _, err := git.Issues.ListProjectIssues(projectPath, &gitlab.ListProjectIssuesOptions{
+	listStart := time.Now()
+	issues, err := listAllIssues(git, projectPath, &gitlab.ListProjectIssuesOptions{
 		State:       &opened,
 		ListOptions: gitlab.ListOptions{PerPage: 100},
 		Labels:      &lab,
 		NotLabels:   &notLabels,
-	})
+	}, maxIssues)
 	if err != nil {
-		log.Fatalf("Failed to list project issues: %v", err)
+		fatal("failed to list project issues", "error", err, "project_path", projectPath)
 	}
 
-	log.Println("Project issues listed successfully.")
-
-	// Create a CSV file
-	currentDateTime := time.Now().Format("2006-01-02_15-04-05")
-	csvFileName := fmt.Sprintf("issues_output_%s.csv", currentDateTime)
+	logger.Info("listed project issues", "project_path", projectPath, "issue_count", len(issues), "duration_ms", time.Since(listStart).Milliseconds())
 
-	outputFile, err := os.Create(csvFileName)
+	detailStart := time.Now()
+	detailedIssues, err := fetchIssueDetails(git, projectPath, issues, concurrency)
 	if err != nil {
-		log.Fatalf("Failed to create CSV file: %v", err)
+		fatal("failed to fetch issue details", "error", err, "project_path", projectPath)
 	}
-	defer outputFile.Close()
+	logger.Info("fetched issue details", "project_path", projectPath, "issue_count", len(detailedIssues), "duration_ms", time.Since(detailStart).Milliseconds())
 
-	log.Printf("CSV file created: %s\n", csvFileName)
-
-	// Create a CSV writer
-	writer := csv.NewWriter(outputFile)
-	defer writer.Flush()
-
-	// Write header to CSV
-	header := []string{"Issue", "Summary", "Assignee", "Author", "Date of Creation"}
-	if err := writer.Write(header); err != nil {
-		log.Fatalf("Failed to write header to CSV: %v", err)
+	renderer, err := newRenderer(outputFormat)
+	if err != nil {
+		fatal("failed to select renderer", "error", err, "format", outputFormat)
 	}
 
-	log.Println("Header written to CSV successfully.")
+	// The CSV renderer keeps the tool's original behavior of writing a
+	// timestamped file; the richer formats are meant for piping or reading
+	// directly, so they go to stdout.
+	out := os.Stdout
+	if outputFormat == "csv" {
+		currentDateTime := time.Now().Format("2006-01-02_15-04-05")
+		csvFileName := fmt.Sprintf("issues_output_%s.csv", currentDateTime)
 
-	// Write details to CSV for each issue
-	for _, issue := range issues {
-		assignee := "Unassigned"
-		if issue.Assignee != nil {
-			assignee = issue.Assignee.Name
-		}
-
-		// Fetch more details about the issue
-		detailedIssue, _, err := git.Issues.GetIssue(projectPath, issue.IID)
+		outputFile, err := os.Create(csvFileName)
 		if err != nil {
-			log.Fatalf("Failed to get detailed issue: %v", err)
+			fatal("failed to create CSV file", "error", err, "file", csvFileName)
 		}
+		defer outputFile.Close()
 
-		// Create a formatted hyperlink for the issue number
-		issueLink := fmt.Sprintf("[#%d](%s)", issue.IID, detailedIssue.WebURL)
-
-		// Write issue details to CSV
-		row := []string{
-			issueLink,
-			detailedIssue.Title,
-			assignee,
-			detailedIssue.Author.Username,
-			detailedIssue.CreatedAt.Format("2006-01-02 15:04:05"),
-		}
+		logger.Info("created CSV file", "file", csvFileName)
+		out = outputFile
+	}
 
-		if err := writer.Write(row); err != nil {
-			log.Fatalf("Failed to write row to CSV: %v", err)
-		}
+	if err := renderer.Render(out, detailedIssues); err != nil {
+		fatal("failed to render issues", "error", err, "format", outputFormat)
 	}
 
-	fmt.Println("CSV file created successfully.")
+	logger.Info("issues rendered successfully", "format", outputFormat, "issue_count", len(detailedIssues))
 }
 
 // resolveHome replaces ~ with current home dir
 func resolveHome(path *string) {
 	expandedPath, err := os.UserHomeDir()
 	if err != nil {
-		log.Println(err)
+		logger.Warn("failed to resolve home directory", "error", err)
 		return
 	}
 	*path = strings.Replace(*path, "~", expandedPath, 1)
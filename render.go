@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Renderer writes a set of detailed issues to w in some output format.
+type Renderer interface {
+	Render(w io.Writer, issues []*gitlab.Issue) error
+}
+
+// newRenderer returns the Renderer for the given -format value.
+func newRenderer(format string) (Renderer, error) {
+	switch format {
+	case "csv":
+		return csvRenderer{}, nil
+	case "json":
+		return jsonRenderer{}, nil
+	case "md":
+		return markdownRenderer{}, nil
+	case "table":
+		return tableRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want csv, json, md, or table)", format)
+	}
+}
+
+// csvRenderer reproduces the original plain CSV dump.
+type csvRenderer struct{}
+
+func (csvRenderer) Render(w io.Writer, issues []*gitlab.Issue) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{"Issue", "Summary", "Assignee", "Author", "Date of Creation"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	for _, issue := range issues {
+		row := []string{
+			fmt.Sprintf("[#%d](%s)", issue.IID, issue.WebURL),
+			issue.Title,
+			assigneeName(issue),
+			issue.Author.Username,
+			formatCreatedAt(issue, "2006-01-02 15:04:05"),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("writing CSV row for issue #%d: %w", issue.IID, err)
+		}
+	}
+
+	return nil
+}
+
+// jsonIssue is the subset of gitlab.Issue fields exposed by jsonRenderer,
+// named to read naturally as machine-consumed pipeline output.
+type jsonIssue struct {
+	IID       int      `json:"iid"`
+	Title     string   `json:"title"`
+	State     string   `json:"state"`
+	Assignee  string   `json:"assignee"`
+	Author    string   `json:"author"`
+	Labels    []string `json:"labels"`
+	Milestone string   `json:"milestone,omitempty"`
+	Weight    int      `json:"weight,omitempty"`
+	CreatedAt string   `json:"created_at"`
+	WebURL    string   `json:"web_url"`
+}
+
+// jsonRenderer emits the issue list as a JSON array, suitable for piping
+// into jq or another tool.
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, issues []*gitlab.Issue) error {
+	out := make([]jsonIssue, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, jsonIssue{
+			IID:       issue.IID,
+			Title:     issue.Title,
+			State:     issue.State,
+			Assignee:  assigneeName(issue),
+			Author:    issue.Author.Username,
+			Labels:    []string(issue.Labels),
+			Milestone: milestoneTitle(issue),
+			Weight:    issue.Weight,
+			CreatedAt: formatCreatedAt(issue, time.RFC3339),
+			WebURL:    issue.WebURL,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encoding issues as JSON: %w", err)
+	}
+	return nil
+}
+
+// markdownRenderer renders a GitHub/GitLab-flavored Markdown table, suitable
+// for pasting into a release notes document or MR description.
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(w io.Writer, issues []*gitlab.Issue) error {
+	fmt.Fprintln(w, "| Issue | Summary | State | Assignee | Labels | Milestone | Weight | Created |")
+	fmt.Fprintln(w, "|---|---|---|---|---|---|---|---|")
+
+	for _, issue := range issues {
+		fmt.Fprintf(w, "| [#%d](%s) | %s | %s | %s | %s | %s | %d | %s |\n",
+			issue.IID, issue.WebURL, issue.Title, issue.State, assigneeName(issue),
+			strings.Join(issue.Labels, ", "), milestoneTitle(issue), issue.Weight, timeAgo(issue.CreatedAt))
+	}
+
+	return nil
+}
+
+// tableRenderer renders a plain, column-aligned table for reading directly
+// in a terminal during a release review session.
+type tableRenderer struct{}
+
+func (tableRenderer) Render(w io.Writer, issues []*gitlab.Issue) error {
+	rows := make([][]string, 0, len(issues)+1)
+	rows = append(rows, []string{"ISSUE", "SUMMARY", "STATE", "ASSIGNEE", "LABELS", "MILESTONE", "WEIGHT", "CREATED"})
+
+	for _, issue := range issues {
+		rows = append(rows, []string{
+			fmt.Sprintf("#%d", issue.IID),
+			issue.Title,
+			issue.State,
+			assigneeName(issue),
+			strings.Join(issue.Labels, ", "),
+			milestoneTitle(issue),
+			fmt.Sprintf("%d", issue.Weight),
+			timeAgo(issue.CreatedAt),
+		})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			fmt.Fprintf(w, "%-*s  ", widths[i], cell)
+		}
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+// assigneeName returns the issue's assignee name, or "Unassigned" if none
+// is set.
+func assigneeName(issue *gitlab.Issue) string {
+	if issue.Assignee != nil {
+		return issue.Assignee.Name
+	}
+	return "Unassigned"
+}
+
+// milestoneTitle returns the issue's milestone title, or "" if none is set.
+func milestoneTitle(issue *gitlab.Issue) string {
+	if issue.Milestone != nil {
+		return issue.Milestone.Title
+	}
+	return ""
+}
+
+// formatCreatedAt formats issue.CreatedAt using layout, or "" if the issue
+// has no creation timestamp. gitlab.Issue.CreatedAt is a *time.Time.
+func formatCreatedAt(issue *gitlab.Issue, layout string) string {
+	if issue.CreatedAt == nil {
+		return ""
+	}
+	return issue.CreatedAt.Format(layout)
+}
+
+// timeAgo renders t as a coarse "N units ago" duration, matching the style
+// glab uses in its terminal issue list. t may be nil, since
+// gitlab.Issue.CreatedAt is a *time.Time.
+func timeAgo(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	d := time.Since(*t)
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/24/30))
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func sampleIssues() []*gitlab.Issue {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []*gitlab.Issue{
+		{
+			IID:       1,
+			Title:     "first issue",
+			State:     "opened",
+			Author:    &gitlab.IssueAuthor{Username: "alice"},
+			Assignee:  &gitlab.IssueAssignee{Name: "Bob"},
+			Labels:    gitlab.Labels{"bug", "p1"},
+			Milestone: &gitlab.Milestone{Title: "v1.0"},
+			Weight:    3,
+			CreatedAt: &created,
+			WebURL:    "https://gitlab.example.com/group/project/-/issues/1",
+		},
+		{
+			IID:    2,
+			Title:  "no timestamp, no assignee, no milestone",
+			State:  "closed",
+			Author: &gitlab.IssueAuthor{Username: "carol"},
+			WebURL: "https://gitlab.example.com/group/project/-/issues/2",
+		},
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	for _, format := range []string{"csv", "json", "md", "table"} {
+		if _, err := newRenderer(format); err != nil {
+			t.Errorf("newRenderer(%q) returned error: %v", format, err)
+		}
+	}
+
+	if _, err := newRenderer("xml"); err == nil {
+		t.Error("newRenderer(\"xml\") expected an error, got nil")
+	}
+}
+
+func TestCSVRendererRender(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (csvRenderer{}).Render(&buf, sampleIssues()); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "[#1](https://gitlab.example.com/group/project/-/issues/1)") {
+		t.Errorf("missing issue 1 link in output:\n%s", out)
+	}
+	if !strings.Contains(out, "2024-01-02 03:04:05") {
+		t.Errorf("missing formatted timestamp in output:\n%s", out)
+	}
+	// Issue 2 has a nil CreatedAt; rendering it must not panic and should
+	// leave the date column empty rather than crash.
+	if !strings.Contains(out, "no timestamp, no assignee, no milestone") {
+		t.Errorf("missing issue 2 row in output:\n%s", out)
+	}
+}
+
+func TestJSONRendererRender(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (jsonRenderer{}).Render(&buf, sampleIssues()); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"iid": 1`) {
+		t.Errorf("missing iid 1 in output:\n%s", out)
+	}
+	if !strings.Contains(out, `"created_at": "2024-01-02T03:04:05Z"`) {
+		t.Errorf("missing formatted created_at in output:\n%s", out)
+	}
+	// Issue 2 has a nil CreatedAt; the renderer must not panic and should
+	// emit an empty string instead.
+	if !strings.Contains(out, `"created_at": ""`) {
+		t.Errorf("expected empty created_at for issue with nil CreatedAt:\n%s", out)
+	}
+}
+
+func TestMarkdownRendererRender(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (markdownRenderer{}).Render(&buf, sampleIssues()); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "| Issue | Summary | State |") {
+		t.Errorf("missing table header in output:\n%s", out)
+	}
+	if !strings.Contains(out, "bug, p1") {
+		t.Errorf("missing joined labels in output:\n%s", out)
+	}
+}
+
+func TestTableRendererRender(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (tableRenderer{}).Render(&buf, sampleIssues()); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "ISSUE") || !strings.Contains(out, "#1") {
+		t.Errorf("missing expected columns in output:\n%s", out)
+	}
+}
+
+func TestTimeAgoNil(t *testing.T) {
+	if got := timeAgo(nil); got != "" {
+		t.Errorf("timeAgo(nil) = %q, want empty string", got)
+	}
+}
+
+func TestFormatCreatedAtNil(t *testing.T) {
+	issue := &gitlab.Issue{}
+	if got := formatCreatedAt(issue, time.RFC3339); got != "" {
+		t.Errorf("formatCreatedAt with nil CreatedAt = %q, want empty string", got)
+	}
+}
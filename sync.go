@@ -0,0 +1,321 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+	"gopkg.in/yaml.v3"
+)
+
+// syncItem is one desired issue state from a sync input file: either an
+// update to an existing issue (IID set) or a brand new issue to create.
+type syncItem struct {
+	IID          int      `yaml:"iid"`
+	Title        string   `yaml:"title"`
+	State        string   `yaml:"state"` // "opened", "closed", or "" to leave unchanged
+	AddLabels    []string `yaml:"add_labels"`
+	RemoveLabels []string `yaml:"remove_labels"`
+	Assignee     string   `yaml:"assignee"`
+	Note         string   `yaml:"note"`
+}
+
+// loadSyncItems reads a sync input file, dispatching on its extension:
+// ".yaml"/".yml" for a YAML document, anything else for CSV.
+func loadSyncItems(path string) ([]syncItem, error) {
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		return loadSyncItemsYAML(path)
+	}
+	return loadSyncItemsCSV(path)
+}
+
+func loadSyncItemsYAML(path string) ([]syncItem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading sync file %s: %w", path, err)
+	}
+
+	var items []syncItem
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("parsing sync file %s: %w", path, err)
+	}
+	return items, nil
+}
+
+// loadSyncItemsCSV expects a header row of
+// iid,title,state,add_labels,remove_labels,assignee,note
+// where add_labels/remove_labels are semicolon-separated.
+func loadSyncItemsCSV(path string) ([]syncItem, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening sync file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing sync file %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var items []syncItem
+	for _, row := range rows[1:] {
+		if len(row) < 7 {
+			return nil, fmt.Errorf("sync file %s: expected 7 columns, got %d", path, len(row))
+		}
+
+		var iid int
+		if row[0] != "" {
+			if _, err := fmt.Sscanf(row[0], "%d", &iid); err != nil {
+				return nil, fmt.Errorf("sync file %s: invalid iid %q: %w", path, row[0], err)
+			}
+		}
+
+		items = append(items, syncItem{
+			IID:          iid,
+			Title:        row[1],
+			State:        row[2],
+			AddLabels:    splitNonEmpty(row[3], ";"),
+			RemoveLabels: splitNonEmpty(row[4], ";"),
+			Assignee:     row[5],
+			Note:         row[6],
+		})
+	}
+
+	return items, nil
+}
+
+func splitNonEmpty(s, sep string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, sep)
+}
+
+// applySyncItem creates or updates a single issue for item, logging the
+// operation. In dry-run mode it logs the planned mutation without calling
+// the API. assigneeIDs caches username-to-ID lookups across a sync run, so a
+// sync file that assigns the same person to many issues only looks them up
+// once.
+func applySyncItem(git *gitlab.Client, projectPath string, item syncItem, dryRun bool, assigneeIDs map[string]int) error {
+	if item.IID == 0 {
+		return createSyncIssue(git, projectPath, item, dryRun, assigneeIDs)
+	}
+	return updateSyncIssue(git, projectPath, item, dryRun, assigneeIDs)
+}
+
+func createSyncIssue(git *gitlab.Client, projectPath string, item syncItem, dryRun bool, assigneeIDs map[string]int) error {
+	if dryRun {
+		logger.Info("dry-run: would create issue", "project_path", projectPath, "title", item.Title, "add_labels", item.AddLabels, "assignee", item.Assignee)
+		return nil
+	}
+
+	opts := &gitlab.CreateIssueOptions{
+		Title:  &item.Title,
+		Labels: (*gitlab.LabelOptions)(&item.AddLabels),
+	}
+
+	if item.Assignee != "" {
+		assigneeID, err := cachedAssigneeID(git, item.Assignee, assigneeIDs)
+		if err != nil {
+			return err
+		}
+		opts.AssigneeIDs = &[]int{assigneeID}
+	}
+
+	start := time.Now()
+	issue, _, err := git.Issues.CreateIssue(projectPath, opts)
+	if err != nil {
+		return fmt.Errorf("creating issue %q: %w", item.Title, err)
+	}
+
+	logger.Info("created issue", "project_path", projectPath, "issue_iid", issue.IID, "web_url", issue.WebURL, "duration_ms", time.Since(start).Milliseconds())
+	return addSyncNote(git, projectPath, issue.IID, item.Note, dryRun)
+}
+
+func updateSyncIssue(git *gitlab.Client, projectPath string, item syncItem, dryRun bool, assigneeIDs map[string]int) error {
+	if dryRun {
+		logger.Info("dry-run: would update issue", "project_path", projectPath, "issue_iid", item.IID,
+			"state", item.State, "add_labels", item.AddLabels, "remove_labels", item.RemoveLabels, "assignee", item.Assignee)
+		return nil
+	}
+
+	opts := &gitlab.UpdateIssueOptions{}
+	if len(item.AddLabels) > 0 {
+		opts.AddLabels = (*gitlab.LabelOptions)(&item.AddLabels)
+	}
+	if len(item.RemoveLabels) > 0 {
+		opts.RemoveLabels = (*gitlab.LabelOptions)(&item.RemoveLabels)
+	}
+	if item.State != "" {
+		event, err := stateEvent(item.State)
+		if err != nil {
+			return fmt.Errorf("updating issue #%d: %w", item.IID, err)
+		}
+		opts.StateEvent = gitlab.String(event)
+	}
+	if item.Assignee != "" {
+		assigneeID, err := cachedAssigneeID(git, item.Assignee, assigneeIDs)
+		if err != nil {
+			return err
+		}
+		opts.AssigneeIDs = &[]int{assigneeID}
+	}
+
+	start := time.Now()
+	issue, _, err := git.Issues.UpdateIssue(projectPath, item.IID, opts)
+	if err != nil {
+		return fmt.Errorf("updating issue #%d: %w", item.IID, err)
+	}
+
+	logger.Info("updated issue", "project_path", projectPath, "issue_iid", issue.IID, "web_url", issue.WebURL, "duration_ms", time.Since(start).Milliseconds())
+	return addSyncNote(git, projectPath, item.IID, item.Note, dryRun)
+}
+
+// resolveAssigneeID looks up a GitLab username and returns its user ID, for
+// use in AssigneeIDs. GitLab's issue API takes user IDs, not usernames.
+func resolveAssigneeID(git *gitlab.Client, username string) (int, error) {
+	users, _, err := git.Users.ListUsers(&gitlab.ListUsersOptions{Username: gitlab.String(username)})
+	if err != nil {
+		return 0, fmt.Errorf("looking up user %q: %w", username, err)
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("no GitLab user found for username %q", username)
+	}
+	return users[0].ID, nil
+}
+
+// cachedAssigneeID is resolveAssigneeID backed by a per-run cache, so a sync
+// file that assigns the same username to many issues only looks it up once.
+func cachedAssigneeID(git *gitlab.Client, username string, cache map[string]int) (int, error) {
+	if id, ok := cache[username]; ok {
+		return id, nil
+	}
+
+	id, err := resolveAssigneeID(git, username)
+	if err != nil {
+		return 0, err
+	}
+	cache[username] = id
+	return id, nil
+}
+
+func addSyncNote(git *gitlab.Client, projectPath string, iid int, note string, dryRun bool) error {
+	if note == "" {
+		return nil
+	}
+	if dryRun {
+		logger.Info("dry-run: would add note", "project_path", projectPath, "issue_iid", iid, "note", note)
+		return nil
+	}
+
+	start := time.Now()
+	if _, _, err := git.Notes.CreateIssueNote(projectPath, iid, &gitlab.CreateIssueNoteOptions{Body: &note}); err != nil {
+		return fmt.Errorf("adding note to issue #%d: %w", iid, err)
+	}
+
+	logger.Info("added note to issue", "project_path", projectPath, "issue_iid", iid, "duration_ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// stateEvent maps a desired issue state to the GitLab state_event value,
+// rejecting anything other than the documented "opened"/"closed" states so
+// a data-entry typo can't silently flip an issue the wrong way.
+func stateEvent(state string) (string, error) {
+	switch state {
+	case "closed":
+		return "close", nil
+	case "opened":
+		return "reopen", nil
+	default:
+		return "", fmt.Errorf("invalid state %q: want \"opened\" or \"closed\"", state)
+	}
+}
+
+// runSync implements the `sync` subcommand: read a CSV/YAML file of
+// desired issue states and apply them via the GitLab API.
+func runSync(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+
+	var syncFile string
+	var configFile string
+	var instanceName string
+	var projectPath string
+	var dryRun bool
+	var logLevel string
+	var logFormat string
+	fs.StringVar(&syncFile, "file", "", "Path to CSV or YAML file of desired issue states")
+	fs.StringVar(&configFile, "config", "", "Path to config file describing GitLab instances")
+	fs.StringVar(&instanceName, "instance", "", "Name of the GitLab instance to use from -config")
+	fs.StringVar(&projectPath, "project", "", "Project path to sync issues into (e.g. f5/volterra/support/technical)")
+	fs.BoolVar(&dryRun, "dry-run", false, "Print planned mutations without calling the API")
+	fs.StringVar(&logLevel, "log-level", "info", "Log level: debug, info, warn, or error")
+	fs.StringVar(&logFormat, "log-format", "text", "Log format: text or json")
+	var tokenFileFlag string
+	fs.StringVar(&tokenFileFlag, "token-file", "", "Explicit path to a GitLab token file (overrides env vars, keyring, and config)")
+
+	fs.Parse(args)
+
+	initLogger(logLevel, logFormat)
+
+	if syncFile == "" {
+		fatal("sync: -file is required")
+	}
+	if projectPath == "" {
+		fatal("sync: -project is required")
+	}
+
+	cfg, err := loadConfig(configFile)
+	if err != nil {
+		fatal("sync: failed to load config", "error", err)
+	}
+
+	instance, err := resolveInstance(cfg, instanceName)
+	if err != nil {
+		fatal("sync: failed to resolve GitLab instance", "error", err)
+	}
+
+	gitlabToken, err := resolveToken(tokenFileFlag, instance.TokenFile)
+	if err != nil {
+		fatal("sync: failed to resolve GitLab token", "error", err)
+	}
+
+	git, err := newGitLabClient(gitlabToken, instance)
+	if err != nil {
+		fatal("sync: failed to create GitLab client", "error", err)
+	}
+
+	username, err := validateToken(git)
+	if err != nil {
+		fatal("sync: failed to validate GitLab token", "error", err)
+	}
+	logger.Info("sync: authenticated with GitLab", "username", username)
+
+	items, err := loadSyncItems(syncFile)
+	if err != nil {
+		fatal("sync: failed to load sync file", "error", err, "file", syncFile)
+	}
+
+	logger.Info("sync: loaded items", "project_path", projectPath, "file", syncFile, "item_count", len(items), "dry_run", dryRun)
+
+	assigneeIDs := make(map[string]int)
+	var failed int
+	for _, item := range items {
+		if err := applySyncItem(git, projectPath, item, dryRun, assigneeIDs); err != nil {
+			logger.Error("sync: error applying item", "error", err, "issue_iid", item.IID, "title", item.Title)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		fatal("sync: items failed", "failed_count", failed, "total_count", len(items))
+	}
+
+	logger.Info("sync: applied items successfully", "project_path", projectPath, "item_count", len(items))
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestStateEvent(t *testing.T) {
+	cases := []struct {
+		state   string
+		want    string
+		wantErr bool
+	}{
+		{"closed", "close", false},
+		{"opened", "reopen", false},
+		{"close", "", true},
+		{"bogus", "", true},
+		{"", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := stateEvent(c.state)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("stateEvent(%q) expected an error, got %q", c.state, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("stateEvent(%q) returned unexpected error: %v", c.state, err)
+		}
+		if got != c.want {
+			t.Errorf("stateEvent(%q) = %q, want %q", c.state, got, c.want)
+		}
+	}
+}
+
+func TestCachedAssigneeIDReusesLookupForRepeatedUsername(t *testing.T) {
+	var lookups int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		json.NewEncoder(w).Encode([]gitlab.User{{ID: 7, Username: r.URL.Query().Get("username")}})
+	})
+
+	client := newTestClient(t, mux)
+	cache := make(map[string]int)
+
+	for i := 0; i < 3; i++ {
+		id, err := cachedAssigneeID(client, "alice", cache)
+		if err != nil {
+			t.Fatalf("cachedAssigneeID returned error: %v", err)
+		}
+		if id != 7 {
+			t.Errorf("cachedAssigneeID = %d, want 7", id)
+		}
+	}
+
+	if lookups != 1 {
+		t.Errorf("got %d ListUsers calls for 3 lookups of the same username, want 1", lookups)
+	}
+}
+
+func TestLoadSyncItemsCSV(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sync.csv")
+	content := "iid,title,state,add_labels,remove_labels,assignee,note\n" +
+		"42,Existing issue,closed,staging-upgrade;production-upgrade,READY-FOR-TEST,alice,done\n" +
+		",New issue,,bug,,,\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	items, err := loadSyncItemsCSV(path)
+	if err != nil {
+		t.Fatalf("loadSyncItemsCSV returned error: %v", err)
+	}
+
+	want := []syncItem{
+		{
+			IID:          42,
+			Title:        "Existing issue",
+			State:        "closed",
+			AddLabels:    []string{"staging-upgrade", "production-upgrade"},
+			RemoveLabels: []string{"READY-FOR-TEST"},
+			Assignee:     "alice",
+			Note:         "done",
+		},
+		{
+			IID:       0,
+			Title:     "New issue",
+			AddLabels: []string{"bug"},
+		},
+	}
+
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("loadSyncItemsCSV = %+v, want %+v", items, want)
+	}
+}
+
+func TestLoadSyncItemsYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sync.yaml")
+	content := `
+- iid: 7
+  title: Promote to next release
+  state: opened
+  add_labels: [next-release]
+  remove_labels: [READY-FOR-TEST]
+  assignee: bob
+  note: promoted
+- title: Brand new issue
+  add_labels: [bug]
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	items, err := loadSyncItems(path)
+	if err != nil {
+		t.Fatalf("loadSyncItems returned error: %v", err)
+	}
+
+	want := []syncItem{
+		{
+			IID:          7,
+			Title:        "Promote to next release",
+			State:        "opened",
+			AddLabels:    []string{"next-release"},
+			RemoveLabels: []string{"READY-FOR-TEST"},
+			Assignee:     "bob",
+			Note:         "promoted",
+		},
+		{
+			Title:     "Brand new issue",
+			AddLabels: []string{"bug"},
+		},
+	}
+
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("loadSyncItems(yaml) = %+v, want %+v", items, want)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = "sre-gitlab-cli"
+
+// resolveToken finds a GitLab token, trying each source in order until one
+// yields a non-empty value: an explicit -token-file, the GITLAB_TOKEN or
+// CI_JOB_TOKEN env vars, the OS keyring, and finally the instance's
+// configured token file (or the legacy ~/.gitlab default). This mirrors the
+// fallback chain glab and goreleaser use so the tool keeps working in CI
+// and on shared workstations where a bare token file isn't practical.
+func resolveToken(explicitTokenFile, instanceTokenFile string) (string, error) {
+	if explicitTokenFile != "" {
+		token, err := readGitLabTokenFromFile(explicitTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("reading -token-file %s: %w", explicitTokenFile, err)
+		}
+		return normalizeToken(token), nil
+	}
+
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		return normalizeToken(token), nil
+	}
+	if token := os.Getenv("CI_JOB_TOKEN"); token != "" {
+		return normalizeToken(token), nil
+	}
+
+	if token, err := keyring.Get(keyringService, "token"); err == nil && token != "" {
+		return normalizeToken(token), nil
+	}
+
+	tokenFile := instanceTokenFile
+	if tokenFile == "" {
+		tokenFile = "~/.gitlab"
+	}
+	resolveHome(&tokenFile)
+
+	token, err := readGitLabTokenFromFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("reading default token file %s: %w", tokenFile, err)
+	}
+	return normalizeToken(token), nil
+}
+
+// normalizeToken strips the legacy "token:" prefix some ~/.gitlab files use.
+func normalizeToken(token string) string {
+	return strings.TrimPrefix(strings.TrimSpace(token), "token:")
+}
+
+// validateToken makes a cheap /user call to confirm the token works, and
+// returns the authenticated username.
+func validateToken(git *gitlab.Client) (string, error) {
+	user, _, err := git.Users.CurrentUser()
+	if err != nil {
+		return "", fmt.Errorf("validating GitLab token: %w", err)
+	}
+	return user.Username, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// withEnv sets an env var for the duration of the test and restores the
+// previous value afterward.
+func withEnv(t *testing.T, key, value string) {
+	t.Helper()
+	old, had := os.LookupEnv(key)
+	if value == "" {
+		os.Unsetenv(key)
+	} else {
+		os.Setenv(key, value)
+	}
+	t.Cleanup(func() {
+		if had {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func writeTokenFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gitlab-token")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing token file: %v", err)
+	}
+	return path
+}
+
+func TestResolveTokenExplicitFileWinsOverEverythingElse(t *testing.T) {
+	keyring.MockInit()
+	withEnv(t, "GITLAB_TOKEN", "env-token")
+	withEnv(t, "CI_JOB_TOKEN", "")
+	keyring.Set(keyringService, "token", "keyring-token")
+
+	explicit := writeTokenFile(t, "token:explicit-token\n")
+
+	got, err := resolveToken(explicit, "")
+	if err != nil {
+		t.Fatalf("resolveToken returned error: %v", err)
+	}
+	if got != "explicit-token" {
+		t.Errorf("resolveToken = %q, want %q", got, "explicit-token")
+	}
+}
+
+func TestResolveTokenEnvVarBeforeKeyringAndFile(t *testing.T) {
+	keyring.MockInit()
+	withEnv(t, "GITLAB_TOKEN", "env-token")
+	withEnv(t, "CI_JOB_TOKEN", "")
+	keyring.Set(keyringService, "token", "keyring-token")
+
+	got, err := resolveToken("", "")
+	if err != nil {
+		t.Fatalf("resolveToken returned error: %v", err)
+	}
+	if got != "env-token" {
+		t.Errorf("resolveToken = %q, want %q", got, "env-token")
+	}
+}
+
+func TestResolveTokenCIJobTokenBeforeKeyring(t *testing.T) {
+	keyring.MockInit()
+	withEnv(t, "GITLAB_TOKEN", "")
+	withEnv(t, "CI_JOB_TOKEN", "ci-token")
+	keyring.Set(keyringService, "token", "keyring-token")
+
+	got, err := resolveToken("", "")
+	if err != nil {
+		t.Fatalf("resolveToken returned error: %v", err)
+	}
+	if got != "ci-token" {
+		t.Errorf("resolveToken = %q, want %q", got, "ci-token")
+	}
+}
+
+func TestResolveTokenKeyringBeforeDefaultFile(t *testing.T) {
+	keyring.MockInit()
+	withEnv(t, "GITLAB_TOKEN", "")
+	withEnv(t, "CI_JOB_TOKEN", "")
+	keyring.Set(keyringService, "token", "keyring-token")
+
+	got, err := resolveToken("", "")
+	if err != nil {
+		t.Fatalf("resolveToken returned error: %v", err)
+	}
+	if got != "keyring-token" {
+		t.Errorf("resolveToken = %q, want %q", got, "keyring-token")
+	}
+}
+
+func TestResolveTokenFallsBackToInstanceFile(t *testing.T) {
+	keyring.MockInit()
+	withEnv(t, "GITLAB_TOKEN", "")
+	withEnv(t, "CI_JOB_TOKEN", "")
+
+	instanceFile := writeTokenFile(t, "instance-token")
+
+	got, err := resolveToken("", instanceFile)
+	if err != nil {
+		t.Fatalf("resolveToken returned error: %v", err)
+	}
+	if got != "instance-token" {
+		t.Errorf("resolveToken = %q, want %q", got, "instance-token")
+	}
+}